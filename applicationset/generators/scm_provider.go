@@ -4,29 +4,52 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/argoproj/argo-cd/v2/applicationset/services/azure_workload_identity"
+	"github.com/argoproj/argo-cd/v2/applicationset/services/github_app"
 	"github.com/argoproj/argo-cd/v2/applicationset/services/scm_provider"
 	argoprojiov1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/applicationset/v1alpha1"
 )
 
 var _ Generator = (*SCMProviderGenerator)(nil)
+var _ scm_provider.SecretGetter = (*SCMProviderGenerator)(nil)
 
 const (
 	DefaultSCMProviderRequeueAfterSeconds = 30 * time.Minute
+	// azureDevOpsResourceScope is the Azure AD App ID for Azure DevOps, requested as an MSAL ".default" scope.
+	azureDevOpsResourceScope = "499b84ac-1321-427f-aa17-267ca6975798/.default"
 )
 
+// repoCredsSecretTypeLabel marks a Secret as an Argo CD repository credential template, scoped to
+// repositories whose URL starts with the "url" key in its Data.
+const repoCredsSecretTypeLabel = "argocd.argoproj.io/secret-type"
+
 type SCMProviderGenerator struct {
 	client client.Client
+	// argocdNamespace is where generated repo-creds Secrets are created, for SSHPrivateKeyRef.
+	argocdNamespace string
 	// Testing hooks.
 	overrideProvider scm_provider.SCMProviderService
+
+	azureWorkloadIdentityMu sync.Mutex
+	// azureWorkloadIdentityTokenSources caches one token source per clientID/tenantID pair so the token
+	// exchange survives across reconciles rather than happening on every GenerateParams call.
+	azureWorkloadIdentityTokenSources map[string]*azure_workload_identity.TokenSource
+
+	githubAppMu sync.Mutex
+	// githubAppTokenSources caches one installation token source per installation ID.
+	githubAppTokenSources map[int64]*github_app.InstallationTokenSource
 }
 
-func NewSCMProviderGenerator(client client.Client) Generator {
-	return &SCMProviderGenerator{client: client}
+func NewSCMProviderGenerator(client client.Client, argocdNamespace string) Generator {
+	return &SCMProviderGenerator{client: client, argocdNamespace: argocdNamespace}
 }
 
 func (g *SCMProviderGenerator) GetRequeueAfter(appSetGenerator *argoprojiov1alpha1.ApplicationSetGenerator) time.Duration {
@@ -57,17 +80,30 @@ func (g *SCMProviderGenerator) GenerateParams(appSetGenerator *argoprojiov1alpha
 	// Create the SCM provider helper.
 	providerConfig := appSetGenerator.SCMProvider
 	var provider scm_provider.SCMProviderService
+	// sshPrivateKeyRef is set below by whichever provider branch matched, if that provider is
+	// configured with an SSHPrivateKeyRef, so it can be turned into a repo-creds Secret afterwards.
+	var sshPrivateKeyRef *argoprojiov1alpha1.SecretRef
 	if g.overrideProvider != nil {
 		provider = g.overrideProvider
 	} else if providerConfig.Github != nil {
-		token, err := g.getSecretRef(ctx, providerConfig.Github.TokenRef, applicationSetInfo.Namespace)
-		if err != nil {
-			return nil, fmt.Errorf("error fetching Github token: %v", err)
+		var token string
+		var err error
+		if providerConfig.Github.AppAuth != nil {
+			token, err = g.githubAppInstallationToken(ctx, providerConfig.Github.AppAuth, providerConfig.Github.API, applicationSetInfo.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("error getting Github App installation token: %v", err)
+			}
+		} else {
+			token, err = g.getSecretRef(ctx, providerConfig.Github.TokenRef, applicationSetInfo.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching Github token: %v", err)
+			}
 		}
 		provider, err = scm_provider.NewGithubProvider(ctx, providerConfig.Github.Organization, token, providerConfig.Github.API, providerConfig.Github.AllBranches)
 		if err != nil {
 			return nil, fmt.Errorf("error initializing Github service: %v", err)
 		}
+		sshPrivateKeyRef = providerConfig.Github.SSHPrivateKeyRef
 	} else if providerConfig.Gitlab != nil {
 		token, err := g.getSecretRef(ctx, providerConfig.Gitlab.TokenRef, applicationSetInfo.Namespace)
 		if err != nil {
@@ -77,38 +113,105 @@ func (g *SCMProviderGenerator) GenerateParams(appSetGenerator *argoprojiov1alpha
 		if err != nil {
 			return nil, fmt.Errorf("error initializing Gitlab service: %v", err)
 		}
+		sshPrivateKeyRef = providerConfig.Gitlab.SSHPrivateKeyRef
 	} else if providerConfig.Gitea != nil {
 		token, err := g.getSecretRef(ctx, providerConfig.Gitea.TokenRef, applicationSetInfo.Namespace)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching Gitea token: %v", err)
 		}
-		provider, err = scm_provider.NewGiteaProvider(ctx, providerConfig.Gitea.Owner, token, providerConfig.Gitea.API, providerConfig.Gitea.AllBranches, providerConfig.Gitea.Insecure)
+		otp, err := g.getSecretRef(ctx, providerConfig.Gitea.OTPRef, applicationSetInfo.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching Gitea OTP secret: %v", err)
+		}
+		provider, err = scm_provider.NewGiteaProvider(ctx, providerConfig.Gitea.Owner, token, otp, providerConfig.Gitea.API, providerConfig.Gitea.AllBranches, providerConfig.Gitea.Insecure)
 		if err != nil {
 			return nil, fmt.Errorf("error initializing Gitea service: %v", err)
 		}
+		sshPrivateKeyRef = providerConfig.Gitea.SSHPrivateKeyRef
+	} else if providerConfig.Forgejo != nil {
+		token, err := g.getSecretRef(ctx, providerConfig.Forgejo.TokenRef, applicationSetInfo.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching Forgejo token: %v", err)
+		}
+		otp, err := g.getSecretRef(ctx, providerConfig.Forgejo.OTPRef, applicationSetInfo.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching Forgejo OTP secret: %v", err)
+		}
+		provider, err = scm_provider.NewForgejoProvider(ctx, providerConfig.Forgejo.Owner, token, otp, providerConfig.Forgejo.API, providerConfig.Forgejo.AllBranches, providerConfig.Forgejo.Insecure)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing Forgejo service: %v", err)
+		}
+		sshPrivateKeyRef = providerConfig.Forgejo.SSHPrivateKeyRef
 	} else if providerConfig.BitbucketServer != nil {
-		providerConfig := providerConfig.BitbucketServer
+		bitbucketServerConfig := providerConfig.BitbucketServer
 		var scmError error
-		if providerConfig.BasicAuth != nil {
-			password, err := g.getSecretRef(ctx, providerConfig.BasicAuth.PasswordRef, applicationSetInfo.Namespace)
+		if bitbucketServerConfig.BasicAuth != nil {
+			password, err := g.getSecretRef(ctx, bitbucketServerConfig.BasicAuth.PasswordRef, applicationSetInfo.Namespace)
 			if err != nil {
 				return nil, fmt.Errorf("error fetching Secret token: %v", err)
 			}
-			provider, scmError = scm_provider.NewBitbucketServerProviderBasicAuth(ctx, providerConfig.BasicAuth.Username, password, providerConfig.API, providerConfig.Project, providerConfig.AllBranches)
+			provider, scmError = scm_provider.NewBitbucketServerProviderBasicAuth(ctx, bitbucketServerConfig.BasicAuth.Username, password, bitbucketServerConfig.API, bitbucketServerConfig.Project, bitbucketServerConfig.AllBranches)
 		} else {
-			provider, scmError = scm_provider.NewBitbucketServerProviderNoAuth(ctx, providerConfig.API, providerConfig.Project, providerConfig.AllBranches)
+			provider, scmError = scm_provider.NewBitbucketServerProviderNoAuth(ctx, bitbucketServerConfig.API, bitbucketServerConfig.Project, bitbucketServerConfig.AllBranches)
 		}
 		if scmError != nil {
 			return nil, fmt.Errorf("error initializing Bitbucket Server service: %v", scmError)
 		}
+		sshPrivateKeyRef = bitbucketServerConfig.SSHPrivateKeyRef
 	} else if providerConfig.AzureDevOps != nil {
-		token, err := g.getSecretRef(ctx, providerConfig.AzureDevOps.AccessTokenRef, applicationSetInfo.Namespace)
+		if providerConfig.AzureDevOps.WorkloadIdentity != nil {
+			bearerToken, err := g.azureWorkloadIdentityToken(ctx, providerConfig.AzureDevOps.WorkloadIdentity)
+			if err != nil {
+				return nil, fmt.Errorf("error getting Azure Workload Identity token: %v", err)
+			}
+			provider, err = scm_provider.NewAzureDevOpsProviderBearerToken(ctx, bearerToken, providerConfig.AzureDevOps.Organization, providerConfig.AzureDevOps.API, providerConfig.AzureDevOps.TeamProject, providerConfig.AzureDevOps.AllBranches)
+			if err != nil {
+				return nil, fmt.Errorf("error initializing Azure Devops service: %v", err)
+			}
+		} else {
+			token, err := g.getSecretRef(ctx, providerConfig.AzureDevOps.AccessTokenRef, applicationSetInfo.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching Azure Devops access token: %v", err)
+			}
+			provider, err = scm_provider.NewAzureDevOpsProvider(ctx, token, providerConfig.AzureDevOps.Organization, providerConfig.AzureDevOps.API, providerConfig.AzureDevOps.TeamProject, providerConfig.AzureDevOps.AllBranches)
+			if err != nil {
+				return nil, fmt.Errorf("error initializing Azure Devops service: %v", err)
+			}
+		}
+		sshPrivateKeyRef = providerConfig.AzureDevOps.SSHPrivateKeyRef
+	} else if providerConfig.BitbucketCloud != nil {
+		appPassword, err := g.getSecretRef(ctx, providerConfig.BitbucketCloud.AppPasswordRef, applicationSetInfo.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching Bitbucket Cloud app password: %v", err)
+		}
+		provider, err = scm_provider.NewBitbucketCloudProvider(providerConfig.BitbucketCloud.Owner, providerConfig.BitbucketCloud.User, appPassword, providerConfig.BitbucketCloud.API, providerConfig.BitbucketCloud.AllBranches)
 		if err != nil {
-			return nil, fmt.Errorf("error fetching Azure Devops access token: %v", err)
+			return nil, fmt.Errorf("error initializing Bitbucket Cloud service: %v", err)
 		}
-		provider, err = scm_provider.NewAzureDevOpsProvider(ctx, token, providerConfig.AzureDevOps.Organization, providerConfig.AzureDevOps.API, providerConfig.AzureDevOps.TeamProject, providerConfig.AzureDevOps.AllBranches)
+		sshPrivateKeyRef = providerConfig.BitbucketCloud.SSHPrivateKeyRef
+	} else if providerConfig.AWSCodeCommit != nil {
+		var err error
+		provider, err = scm_provider.NewAWSCodeCommitProvider(ctx, providerConfig.AWSCodeCommit.Region, providerConfig.AWSCodeCommit.Role, providerConfig.AWSCodeCommit.TagFilters, providerConfig.AWSCodeCommit.AllBranches)
 		if err != nil {
-			return nil, fmt.Errorf("error initializing Azure Devops service: %v", err)
+			return nil, fmt.Errorf("error initializing AWS CodeCommit service: %v", err)
+		}
+		sshPrivateKeyRef = providerConfig.AWSCodeCommit.SSHPrivateKeyRef
+	} else if providerConfig.Generic != nil {
+		factory, err := scm_provider.GetSCMProvider(providerConfig.Generic.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error finding SCM provider: %v", err)
+		}
+		rawConfig, err := g.getConfigMapData(ctx, providerConfig.Generic.ConfigMapRef, applicationSetInfo.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error reading SCM provider ConfigMap %q: %v", providerConfig.Generic.ConfigMapRef, err)
+		}
+		if providerConfig.Generic.SecretRef != nil {
+			rawConfig["secretName"] = providerConfig.Generic.SecretRef.SecretName
+			rawConfig["secretKey"] = providerConfig.Generic.SecretRef.Key
+		}
+		provider, err = factory.Build(ctx, rawConfig, applicationSetInfo.Namespace, g)
+		if err != nil {
+			return nil, fmt.Errorf("error building SCM provider %q: %v", providerConfig.Generic.Name, err)
 		}
 	} else {
 		return nil, fmt.Errorf("no SCM provider implementation configured")
@@ -119,9 +222,30 @@ func (g *SCMProviderGenerator) GenerateParams(appSetGenerator *argoprojiov1alpha
 	if err != nil {
 		return nil, fmt.Errorf("error listing repos: %v", err)
 	}
+
+	// sshPrivateKeySecretNames maps each distinct sshURLPrefix among the discovered repos to its
+	// repo-creds Secret name. A single provider scan can return repos at varying path depths (e.g.
+	// GitLab with IncludeSubgroups), so one Secret scoped to the first repo's prefix isn't enough to
+	// cover all of them.
+	var sshPrivateKeySecretNames map[string]string
+	if providerConfig.CloneProtocol == "ssh" && sshPrivateKeyRef != nil && len(repos) > 0 {
+		sshPrivateKeySecretNames = map[string]string{}
+		for _, repo := range repos {
+			prefix := sshURLPrefix(repo.URL)
+			if _, ok := sshPrivateKeySecretNames[prefix]; ok {
+				continue
+			}
+			name, err := g.ensureRepoCredsSecret(ctx, sshPrivateKeyRef, prefix, applicationSetInfo.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("error creating SSH repo-creds Secret: %v", err)
+			}
+			sshPrivateKeySecretNames[prefix] = name
+		}
+	}
+
 	params := make([]map[string]string, 0, len(repos))
 	for _, repo := range repos {
-		params = append(params, map[string]string{
+		param := map[string]string{
 			"organization":     repo.Organization,
 			"repository":       repo.Repository,
 			"url":              repo.URL,
@@ -129,11 +253,145 @@ func (g *SCMProviderGenerator) GenerateParams(appSetGenerator *argoprojiov1alpha
 			"sha":              repo.SHA,
 			"labels":           strings.Join(repo.Labels, ","),
 			"branchNormalized": sanitizeName(repo.Branch),
-		})
+		}
+		if sshPrivateKeySecretNames != nil {
+			param["sshPrivateKeySecret"] = sshPrivateKeySecretNames[sshURLPrefix(repo.URL)]
+		}
+		params = append(params, param)
 	}
 	return params, nil
 }
 
+// sshURLPrefix trims the final path segment off an SSH clone URL (e.g. "git@github.com:org/repo.git"
+// -> "git@github.com:org/"), giving the URL prefix a repo-creds Secret is scoped to.
+func sshURLPrefix(url string) string {
+	if idx := strings.LastIndex(url, "/"); idx != -1 {
+		return url[:idx+1]
+	}
+	return url
+}
+
+// ensureRepoCredsSecret creates or updates an argocd.argoproj.io/secret-type: repo-creds Secret in the
+// Argo CD namespace, scoped to urlPrefix, so Argo CD can clone the discovered repositories over SSH
+// without requiring one pre-provisioned repo credential per generated Application. It returns the
+// Secret's name.
+func (g *SCMProviderGenerator) ensureRepoCredsSecret(ctx context.Context, ref *argoprojiov1alpha1.SecretRef, urlPrefix, namespace string) (string, error) {
+	sshPrivateKey, err := g.getSecretRef(ctx, ref, namespace)
+	if err != nil {
+		return "", fmt.Errorf("error fetching SSH private key: %v", err)
+	}
+
+	name := fmt.Sprintf("appset-scm-repo-creds-%s", sanitizeName(urlPrefix))
+	secret := &corev1.Secret{}
+	err = g.client.Get(ctx, client.ObjectKey{Name: name, Namespace: g.argocdNamespace}, secret)
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: g.argocdNamespace,
+				Labels:    map[string]string{repoCredsSecretTypeLabel: "repo-creds"},
+			},
+		}
+	} else if err != nil {
+		return "", fmt.Errorf("error fetching repo-creds Secret %s/%s: %v", g.argocdNamespace, name, err)
+	}
+
+	secret.Data = map[string][]byte{
+		"url":           []byte(urlPrefix),
+		"sshPrivateKey": []byte(sshPrivateKey),
+	}
+	if secret.ResourceVersion == "" {
+		if err := g.client.Create(ctx, secret); err != nil {
+			return "", fmt.Errorf("error creating repo-creds Secret %s/%s: %v", g.argocdNamespace, name, err)
+		}
+	} else if err := g.client.Update(ctx, secret); err != nil {
+		return "", fmt.Errorf("error updating repo-creds Secret %s/%s: %v", g.argocdNamespace, name, err)
+	}
+	return name, nil
+}
+
+// azureWorkloadIdentityToken returns a valid Azure AD access token for the Azure DevOps resource,
+// reusing a cached token source across calls so the MSAL exchange doesn't run on every reconcile.
+func (g *SCMProviderGenerator) azureWorkloadIdentityToken(ctx context.Context, workloadIdentity *argoprojiov1alpha1.SCMProviderGeneratorAzureDevOpsWorkloadIdentity) (string, error) {
+	g.azureWorkloadIdentityMu.Lock()
+	if g.azureWorkloadIdentityTokenSources == nil {
+		g.azureWorkloadIdentityTokenSources = map[string]*azure_workload_identity.TokenSource{}
+	}
+	key := workloadIdentity.TenantID + "/" + workloadIdentity.ClientID
+	tokenSource, ok := g.azureWorkloadIdentityTokenSources[key]
+	if !ok {
+		var err error
+		tokenSource, err = azure_workload_identity.NewTokenSource(azure_workload_identity.Config{
+			ClientID: workloadIdentity.ClientID,
+			TenantID: workloadIdentity.TenantID,
+			Scope:    azureDevOpsResourceScope,
+		})
+		if err != nil {
+			g.azureWorkloadIdentityMu.Unlock()
+			return "", err
+		}
+		g.azureWorkloadIdentityTokenSources[key] = tokenSource
+	}
+	g.azureWorkloadIdentityMu.Unlock()
+
+	return tokenSource.Token(ctx)
+}
+
+// githubAppInstallationToken returns a valid GitHub App installation access token, reusing a cached
+// token source across calls so the app mints a fresh JWT/installation token pair only when needed.
+func (g *SCMProviderGenerator) githubAppInstallationToken(ctx context.Context, appAuth *argoprojiov1alpha1.SCMProviderGeneratorGithubAppAuth, api, namespace string) (string, error) {
+	privateKey, err := g.getSecretRef(ctx, appAuth.PrivateKeyRef, namespace)
+	if err != nil {
+		return "", fmt.Errorf("error fetching Github App private key: %v", err)
+	}
+
+	g.githubAppMu.Lock()
+	if g.githubAppTokenSources == nil {
+		g.githubAppTokenSources = map[int64]*github_app.InstallationTokenSource{}
+	}
+	tokenSource, ok := g.githubAppTokenSources[appAuth.InstallationID]
+	if !ok {
+		tokenSource, err = github_app.NewInstallationTokenSource(github_app.Config{
+			AppID:          appAuth.AppID,
+			InstallationID: appAuth.InstallationID,
+			PrivateKey:     []byte(privateKey),
+			API:            api,
+		})
+		if err != nil {
+			g.githubAppMu.Unlock()
+			return "", err
+		}
+		g.githubAppTokenSources[appAuth.InstallationID] = tokenSource
+	}
+	g.githubAppMu.Unlock()
+
+	return tokenSource.Token(ctx)
+}
+
+// GetSecretRef implements scm_provider.SecretGetter, so Generic SCM provider factories can resolve
+// secrets without needing their own controller-runtime client.
+func (g *SCMProviderGenerator) GetSecretRef(ctx context.Context, ref *argoprojiov1alpha1.SecretRef, namespace string) (string, error) {
+	return g.getSecretRef(ctx, ref, namespace)
+}
+
+func (g *SCMProviderGenerator) getConfigMapData(ctx context.Context, name, namespace string) (map[string]string, error) {
+	if name == "" {
+		return map[string]string{}, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := g.client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, configMap)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching ConfigMap %s/%s: %v", namespace, name, err)
+	}
+
+	rawConfig := make(map[string]string, len(configMap.Data))
+	for k, v := range configMap.Data {
+		rawConfig[k] = v
+	}
+	return rawConfig, nil
+}
+
 func (g *SCMProviderGenerator) getSecretRef(ctx context.Context, ref *argoprojiov1alpha1.SecretRef, namespace string) (string, error) {
 	if ref == nil {
 		return "", nil
@@ -0,0 +1,44 @@
+package azure_workload_identity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenSourceTokenStillValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		expiry time.Time
+		want   bool
+	}{
+		{
+			name:   "well before expiry",
+			expiry: time.Now().Add(30 * time.Minute),
+			want:   true,
+		},
+		{
+			name:   "just inside the refresh skew",
+			expiry: time.Now().Add(tokenRefreshSkew - 10*time.Second),
+			want:   false,
+		},
+		{
+			name:   "just outside the refresh skew",
+			expiry: time.Now().Add(tokenRefreshSkew + 10*time.Second),
+			want:   true,
+		},
+		{
+			name:   "already expired",
+			expiry: time.Now().Add(-5 * time.Minute),
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TokenSource{token: "cached-token", expiry: tt.expiry}
+			if got := s.tokenStillValid(); got != tt.want {
+				t.Errorf("tokenStillValid() with expiry %v = %v, want %v", tt.expiry, got, tt.want)
+			}
+		})
+	}
+}
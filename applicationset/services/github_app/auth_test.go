@@ -0,0 +1,93 @@
+package github_app
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func generateTestPrivateKeyPEM(t *testing.T) ([]byte, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating RSA key: %v", err)
+	}
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	return pem.EncodeToMemory(block), key
+}
+
+func TestSignAppJWT(t *testing.T) {
+	pemBytes, key := generateTestPrivateKeyPEM(t)
+	source, err := NewInstallationTokenSource(Config{AppID: 12345, InstallationID: 1, PrivateKey: pemBytes})
+	if err != nil {
+		t.Fatalf("NewInstallationTokenSource() error = %v", err)
+	}
+
+	tokenString, err := source.signAppJWT()
+	if err != nil {
+		t.Fatalf("signAppJWT() error = %v", err)
+	}
+
+	claims := &jwt.RegisteredClaims{}
+	parsed, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return &key.PublicKey, nil
+	})
+	if err != nil {
+		t.Fatalf("error parsing signed JWT: %v", err)
+	}
+	if !parsed.Valid {
+		t.Fatal("signed JWT did not validate against its own public key")
+	}
+	if claims.Issuer != "12345" {
+		t.Errorf("Issuer = %q, want %q", claims.Issuer, "12345")
+	}
+	if !claims.ExpiresAt.After(time.Now()) {
+		t.Errorf("ExpiresAt = %v, want a time in the future", claims.ExpiresAt)
+	}
+	if !claims.IssuedAt.Before(time.Now()) {
+		t.Errorf("IssuedAt = %v, want a time in the past (clock drift allowance)", claims.IssuedAt)
+	}
+}
+
+func TestInstallationTokenSourceTokenStillValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		expiry time.Time
+		want   bool
+	}{
+		{
+			name:   "fresh token minted just now",
+			expiry: time.Now().Add(time.Hour),
+			want:   true,
+		},
+		{
+			name:   "just inside the refresh skew",
+			expiry: time.Now().Add(tokenRefreshSkew - time.Second),
+			want:   false,
+		},
+		{
+			name:   "exactly on the refresh skew boundary",
+			expiry: time.Now().Add(tokenRefreshSkew),
+			want:   false,
+		},
+		{
+			name:   "expired installation token",
+			expiry: time.Now().Add(-10 * time.Minute),
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &InstallationTokenSource{token: "cached-token", expiry: tt.expiry}
+			if got := s.tokenStillValid(); got != tt.want {
+				t.Errorf("tokenStillValid() with expiry %v = %v, want %v", tt.expiry, got, tt.want)
+			}
+		})
+	}
+}
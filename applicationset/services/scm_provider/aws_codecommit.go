@@ -0,0 +1,190 @@
+package scm_provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/codecommit"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	argoprojiov1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/applicationset/v1alpha1"
+)
+
+// AWSCodeCommitProvider talks to AWS CodeCommit. Credentials come from the standard AWS SDK chain,
+// which already resolves IRSA/web-identity tokens on EKS, optionally assuming Role.
+type AWSCodeCommitProvider struct {
+	client      *codecommit.Client
+	accountID   string
+	region      string
+	tagFilters  []*argoprojiov1alpha1.TagFilter
+	allBranches bool
+}
+
+var _ SCMProviderService = &AWSCodeCommitProvider{}
+
+func NewAWSCodeCommitProvider(ctx context.Context, region, role string, tagFilters []*argoprojiov1alpha1.TagFilter, allBranches bool) (*AWSCodeCommitProvider, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("error loading AWS config: %v", err)
+	}
+	if role != "" {
+		stsClient := sts.NewFromConfig(cfg)
+		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(stsClient, role))
+	}
+
+	accountID, err := accountIDFromCredentials(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving AWS account ID: %v", err)
+	}
+
+	return &AWSCodeCommitProvider{
+		client:      codecommit.NewFromConfig(cfg),
+		accountID:   accountID,
+		region:      region,
+		tagFilters:  tagFilters,
+		allBranches: allBranches,
+	}, nil
+}
+
+func accountIDFromCredentials(ctx context.Context, cfg aws.Config) (string, error) {
+	identity, err := sts.NewFromConfig(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(identity.Account), nil
+}
+
+func (g *AWSCodeCommitProvider) ListRepos(ctx context.Context, cloneProtocol string) ([]*Repository, error) {
+	var repoNames []string
+	paginator := codecommit.NewListRepositoriesPaginator(g.client, &codecommit.ListRepositoriesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing CodeCommit repositories: %v", err)
+		}
+		for _, meta := range page.Repositories {
+			repoNames = append(repoNames, aws.ToString(meta.RepositoryName))
+		}
+	}
+
+	repos := make([]*Repository, 0, len(repoNames))
+	for _, name := range repoNames {
+		out, err := g.client.GetRepository(ctx, &codecommit.GetRepositoryInput{RepositoryName: aws.String(name)})
+		if err != nil {
+			return nil, fmt.Errorf("error getting CodeCommit repository %s: %v", name, err)
+		}
+
+		labels, err := g.repoLabels(ctx, aws.ToString(out.RepositoryMetadata.Arn))
+		if err != nil {
+			return nil, err
+		}
+		if !matchesTagFilters(labels, g.tagFilters) {
+			continue
+		}
+
+		repos = append(repos, &Repository{
+			Organization: g.accountID,
+			Repository:   name,
+			Branch:       aws.ToString(out.RepositoryMetadata.DefaultBranch),
+			URL:          codeCommitCloneURL(g.region, name, cloneProtocol),
+			Labels:       labels,
+		})
+	}
+	return repos, nil
+}
+
+func (g *AWSCodeCommitProvider) GetBranches(ctx context.Context, repo *Repository) ([]*Repository, error) {
+	// AWS returns an empty DefaultBranch for a repository with no commits yet. There's no branch to
+	// resolve in that case, so skip it rather than calling GetBranch with an empty BranchName and
+	// failing the whole scan.
+	if !g.allBranches && repo.Branch == "" {
+		return nil, nil
+	}
+
+	branchNames := []string{repo.Branch}
+	if g.allBranches {
+		branchNames = nil
+		paginator := codecommit.NewListBranchesPaginator(g.client, &codecommit.ListBranchesInput{RepositoryName: aws.String(repo.Repository)})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("error listing branches for %s: %v", repo.Repository, err)
+			}
+			branchNames = append(branchNames, page.Branches...)
+		}
+	}
+
+	branches := make([]*Repository, 0, len(branchNames))
+	for _, name := range branchNames {
+		out, err := g.client.GetBranch(ctx, &codecommit.GetBranchInput{RepositoryName: aws.String(repo.Repository), BranchName: aws.String(name)})
+		if err != nil {
+			return nil, fmt.Errorf("error getting branch %s for %s: %v", name, repo.Repository, err)
+		}
+		branches = append(branches, &Repository{
+			Organization: repo.Organization,
+			Repository:   repo.Repository,
+			URL:          repo.URL,
+			Branch:       name,
+			SHA:          aws.ToString(out.Branch.CommitId),
+			Labels:       repo.Labels,
+		})
+	}
+	return branches, nil
+}
+
+func (g *AWSCodeCommitProvider) repoLabels(ctx context.Context, repoArn string) ([]string, error) {
+	out, err := g.client.ListTagsForResource(ctx, &codecommit.ListTagsForResourceInput{ResourceArn: aws.String(repoArn)})
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags for %s: %v", repoArn, err)
+	}
+	labels := make([]string, 0, len(out.Tags))
+	for key, value := range out.Tags {
+		labels = append(labels, fmt.Sprintf("%s=%s", key, value))
+	}
+	return labels, nil
+}
+
+func matchesTagFilters(labels []string, filters []*argoprojiov1alpha1.TagFilter) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	tags := make(map[string]string, len(labels))
+	for _, label := range labels {
+		if key, value, found := splitLabel(label); found {
+			tags[key] = value
+		}
+	}
+	for _, filter := range filters {
+		value, ok := tags[filter.Key]
+		if !ok {
+			return false
+		}
+		if filter.Value != "" && value != filter.Value {
+			return false
+		}
+	}
+	return true
+}
+
+func splitLabel(label string) (string, string, bool) {
+	for i := 0; i < len(label); i++ {
+		if label[i] == '=' {
+			return label[:i], label[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+func codeCommitCloneURL(region, repoName, cloneProtocol string) string {
+	switch cloneProtocol {
+	case "ssh":
+		return fmt.Sprintf("ssh://git-codecommit.%s.amazonaws.com/v1/repos/%s", region, repoName)
+	case "grc":
+		return fmt.Sprintf("codecommit::%s://%s", region, repoName)
+	default:
+		return fmt.Sprintf("https://git-codecommit.%s.amazonaws.com/v1/repos/%s", region, repoName)
+	}
+}
@@ -0,0 +1,119 @@
+package scm_provider
+
+import (
+	"testing"
+
+	argoprojiov1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/applicationset/v1alpha1"
+)
+
+func TestCodeCommitCloneURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		region        string
+		repoName      string
+		cloneProtocol string
+		want          string
+	}{
+		{
+			name:          "https default",
+			region:        "us-east-1",
+			repoName:      "my-repo",
+			cloneProtocol: "",
+			want:          "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/my-repo",
+		},
+		{
+			name:          "ssh",
+			region:        "eu-west-1",
+			repoName:      "my-repo",
+			cloneProtocol: "ssh",
+			want:          "ssh://git-codecommit.eu-west-1.amazonaws.com/v1/repos/my-repo",
+		},
+		{
+			name:          "grc",
+			region:        "us-west-2",
+			repoName:      "my-repo",
+			cloneProtocol: "grc",
+			want:          "codecommit::us-west-2://my-repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := codeCommitCloneURL(tt.region, tt.repoName, tt.cloneProtocol); got != tt.want {
+				t.Errorf("codeCommitCloneURL(%q, %q, %q) = %q, want %q", tt.region, tt.repoName, tt.cloneProtocol, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesTagFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  []string
+		filters []*argoprojiov1alpha1.TagFilter
+		want    bool
+	}{
+		{
+			name:    "no filters always matches",
+			labels:  []string{"env=prod"},
+			filters: nil,
+			want:    true,
+		},
+		{
+			name:    "key and value match",
+			labels:  []string{"env=prod", "team=infra"},
+			filters: []*argoprojiov1alpha1.TagFilter{{Key: "env", Value: "prod"}},
+			want:    true,
+		},
+		{
+			name:    "value mismatch",
+			labels:  []string{"env=staging"},
+			filters: []*argoprojiov1alpha1.TagFilter{{Key: "env", Value: "prod"}},
+			want:    false,
+		},
+		{
+			name:    "key missing",
+			labels:  []string{"team=infra"},
+			filters: []*argoprojiov1alpha1.TagFilter{{Key: "env", Value: "prod"}},
+			want:    false,
+		},
+		{
+			name:    "key-only filter matches any value",
+			labels:  []string{"env=prod"},
+			filters: []*argoprojiov1alpha1.TagFilter{{Key: "env"}},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesTagFilters(tt.labels, tt.filters); got != tt.want {
+				t.Errorf("matchesTagFilters(%v, %v) = %v, want %v", tt.labels, tt.filters, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitLabel(t *testing.T) {
+	tests := []struct {
+		name      string
+		label     string
+		wantKey   string
+		wantValue string
+		wantFound bool
+	}{
+		{name: "simple pair", label: "env=prod", wantKey: "env", wantValue: "prod", wantFound: true},
+		{name: "value contains equals", label: "query=a=b", wantKey: "query", wantValue: "a=b", wantFound: true},
+		{name: "no separator", label: "noequals", wantFound: false},
+		{name: "empty value", label: "env=", wantKey: "env", wantValue: "", wantFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, value, found := splitLabel(tt.label)
+			if found != tt.wantFound || key != tt.wantKey || value != tt.wantValue {
+				t.Errorf("splitLabel(%q) = (%q, %q, %v), want (%q, %q, %v)", tt.label, key, value, found, tt.wantKey, tt.wantValue, tt.wantFound)
+			}
+		})
+	}
+}
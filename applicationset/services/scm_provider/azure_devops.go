@@ -0,0 +1,139 @@
+package scm_provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+// AzureDevOpsProvider talks to Azure DevOps Services via the azure-devops-go-api client.
+type AzureDevOpsProvider struct {
+	connection   *azuredevops.Connection
+	organization string
+	teamProject  string
+	allBranches  bool
+}
+
+var _ SCMProviderService = &AzureDevOpsProvider{}
+
+// NewAzureDevOpsProvider authenticates with a personal access token.
+func NewAzureDevOpsProvider(ctx context.Context, token, organization, url, teamProject string, allBranches bool) (*AzureDevOpsProvider, error) {
+	orgURL := azureDevOpsOrgURL(url, organization)
+	return &AzureDevOpsProvider{
+		connection:   azuredevops.NewPatConnection(orgURL, token),
+		organization: organization,
+		teamProject:  teamProject,
+		allBranches:  allBranches,
+	}, nil
+}
+
+// NewAzureDevOpsProviderBearerToken authenticates with a bearer token, e.g. one obtained via Azure
+// Workload Identity, instead of a personal access token.
+func NewAzureDevOpsProviderBearerToken(ctx context.Context, bearerToken, organization, url, teamProject string, allBranches bool) (*AzureDevOpsProvider, error) {
+	orgURL := azureDevOpsOrgURL(url, organization)
+	connection := azuredevops.NewConnection(orgURL)
+	connection.AuthorizationString = "Bearer " + bearerToken
+	return &AzureDevOpsProvider{
+		connection:   connection,
+		organization: organization,
+		teamProject:  teamProject,
+		allBranches:  allBranches,
+	}, nil
+}
+
+func azureDevOpsOrgURL(api, organization string) string {
+	if api != "" {
+		return fmt.Sprintf("%s/%s", api, organization)
+	}
+	return fmt.Sprintf("https://dev.azure.com/%s", organization)
+}
+
+func (g *AzureDevOpsProvider) ListRepos(ctx context.Context, cloneProtocol string) ([]*Repository, error) {
+	client, err := git.NewClient(ctx, g.connection)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Azure DevOps git client: %v", err)
+	}
+
+	azureRepos, err := client.GetRepositories(ctx, git.GetRepositoriesArgs{Project: &g.teamProject})
+	if err != nil {
+		return nil, fmt.Errorf("error listing Azure DevOps repositories: %v", err)
+	}
+
+	repos := make([]*Repository, 0, len(*azureRepos))
+	for _, repo := range *azureRepos {
+		if repo.Name == nil {
+			continue
+		}
+		url := repo.RemoteUrl
+		if cloneProtocol == "ssh" {
+			url = repo.SshUrl
+		}
+		if url == nil {
+			return nil, fmt.Errorf("repository %s has no %s clone URL", *repo.Name, cloneProtocol)
+		}
+		repos = append(repos, &Repository{
+			Organization: g.organization,
+			Repository:   *repo.Name,
+			Branch:       azureDevOpsDefaultBranch(repo.DefaultBranch),
+			URL:          *url,
+			Labels:       []string{},
+		})
+	}
+	return repos, nil
+}
+
+// azureDevOpsDefaultBranch strips the "refs/heads/" prefix Azure DevOps puts on
+// GitRepository.DefaultBranch, and tolerates a nil pointer for a repository with no commits yet.
+func azureDevOpsDefaultBranch(ref *string) string {
+	if ref == nil {
+		return ""
+	}
+	return strings.TrimPrefix(*ref, "refs/heads/")
+}
+
+func (g *AzureDevOpsProvider) GetBranches(ctx context.Context, repo *Repository) ([]*Repository, error) {
+	client, err := git.NewClient(ctx, g.connection)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Azure DevOps git client: %v", err)
+	}
+
+	if !g.allBranches {
+		branch, err := client.GetBranch(ctx, git.GetBranchArgs{RepositoryId: &repo.Repository, Project: &g.teamProject, Name: &repo.Branch})
+		if err != nil {
+			return nil, fmt.Errorf("error getting branch %s for %s: %v", repo.Branch, repo.Repository, err)
+		}
+		return []*Repository{azureDevOpsBranchRepository(repo, branch)}, nil
+	}
+
+	azureBranches, err := client.GetBranches(ctx, git.GetBranchesArgs{RepositoryId: &repo.Repository, Project: &g.teamProject})
+	if err != nil {
+		return nil, fmt.Errorf("error listing branches for %s: %v", repo.Repository, err)
+	}
+
+	branches := make([]*Repository, 0, len(*azureBranches))
+	for i := range *azureBranches {
+		branches = append(branches, azureDevOpsBranchRepository(repo, &(*azureBranches)[i]))
+	}
+	return branches, nil
+}
+
+// azureDevOpsBranchRepository turns a git.BranchStats into a Repository, tolerating the nil Name/
+// Commit/CommitId pointers the SDK returns for e.g. a branch with no commits yet.
+func azureDevOpsBranchRepository(repo *Repository, branch *git.BranchStats) *Repository {
+	out := &Repository{
+		Organization: repo.Organization,
+		Repository:   repo.Repository,
+		URL:          repo.URL,
+		Labels:       repo.Labels,
+	}
+	if branch.Name != nil {
+		out.Branch = *branch.Name
+	}
+	if branch.Commit != nil && branch.Commit.CommitId != nil {
+		out.SHA = *branch.Commit.CommitId
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+package scm_provider
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+)
+
+func TestAzureDevOpsOrgURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		api          string
+		organization string
+		want         string
+	}{
+		{name: "default cloud URL", api: "", organization: "myorg", want: "https://dev.azure.com/myorg"},
+		{name: "on-prem collection URL", api: "https://devops.example.com/tfs", organization: "myorg", want: "https://devops.example.com/tfs/myorg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := azureDevOpsOrgURL(tt.api, tt.organization); got != tt.want {
+				t.Errorf("azureDevOpsOrgURL(%q, %q) = %q, want %q", tt.api, tt.organization, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAzureDevOpsDefaultBranch(t *testing.T) {
+	refHeads := "refs/heads/main"
+	plain := "develop"
+
+	tests := []struct {
+		name string
+		ref  *string
+		want string
+	}{
+		{name: "refs/heads prefix stripped", ref: &refHeads, want: "main"},
+		{name: "no prefix left alone", ref: &plain, want: "develop"},
+		{name: "nil for a repo with no commits yet", ref: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := azureDevOpsDefaultBranch(tt.ref); got != tt.want {
+				t.Errorf("azureDevOpsDefaultBranch(%v) = %q, want %q", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAzureDevOpsBranchRepository(t *testing.T) {
+	repo := &Repository{Organization: "myorg", Repository: "my-repo", URL: "https://example.com/my-repo", Labels: []string{}}
+	branchName := "main"
+	commitID := "abc123"
+
+	tests := []struct {
+		name       string
+		branch     *git.BranchStats
+		wantBranch string
+		wantSHA    string
+	}{
+		{
+			name:       "fully populated",
+			branch:     &git.BranchStats{Name: &branchName, Commit: &git.GitCommitRef{CommitId: &commitID}},
+			wantBranch: "main",
+			wantSHA:    "abc123",
+		},
+		{
+			name:       "nil name and commit, e.g. a newly created repo",
+			branch:     &git.BranchStats{},
+			wantBranch: "",
+			wantSHA:    "",
+		},
+		{
+			name:       "commit present but nil CommitId",
+			branch:     &git.BranchStats{Name: &branchName, Commit: &git.GitCommitRef{}},
+			wantBranch: "main",
+			wantSHA:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := azureDevOpsBranchRepository(repo, tt.branch)
+			if got.Branch != tt.wantBranch || got.SHA != tt.wantSHA {
+				t.Errorf("azureDevOpsBranchRepository() = {Branch: %q, SHA: %q}, want {Branch: %q, SHA: %q}", got.Branch, got.SHA, tt.wantBranch, tt.wantSHA)
+			}
+		})
+	}
+}
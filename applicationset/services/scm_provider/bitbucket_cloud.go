@@ -0,0 +1,178 @@
+package scm_provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const defaultBitbucketCloudAPI = "https://api.bitbucket.org/2.0"
+
+// BitbucketCloudProvider talks to the Bitbucket Cloud (bitbucket.org) REST API v2.0, which is
+// distinct from Bitbucket Server's API and authenticates with app passwords rather than PATs.
+type BitbucketCloudProvider struct {
+	client      *http.Client
+	user        string
+	appPassword string
+	owner       string
+	baseURL     string
+	allBranches bool
+}
+
+var _ SCMProviderService = &BitbucketCloudProvider{}
+
+func NewBitbucketCloudProvider(owner, user, appPassword, api string, allBranches bool) (*BitbucketCloudProvider, error) {
+	if api == "" {
+		api = defaultBitbucketCloudAPI
+	}
+	return &BitbucketCloudProvider{
+		client:      http.DefaultClient,
+		user:        user,
+		appPassword: appPassword,
+		owner:       owner,
+		baseURL:     api,
+		allBranches: allBranches,
+	}, nil
+}
+
+type bitbucketCloudRepo struct {
+	Slug     string `json:"slug"`
+	FullName string `json:"full_name"`
+	Links    struct {
+		Clone []struct {
+			Name string `json:"name"`
+			Href string `json:"href"`
+		} `json:"clone"`
+	} `json:"links"`
+	MainBranch struct {
+		Name string `json:"name"`
+	} `json:"mainbranch"`
+}
+
+type bitbucketCloudPagedRepos struct {
+	Values []bitbucketCloudRepo `json:"values"`
+	Next   string               `json:"next"`
+}
+
+type bitbucketCloudBranch struct {
+	Name   string `json:"name"`
+	Target struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
+}
+
+type bitbucketCloudPagedBranches struct {
+	Values []bitbucketCloudBranch `json:"values"`
+	Next   string                 `json:"next"`
+}
+
+func (g *BitbucketCloudProvider) ListRepos(ctx context.Context, cloneProtocol string) ([]*Repository, error) {
+	url := fmt.Sprintf("%s/repositories/%s?pagelen=100", g.baseURL, g.owner)
+	repos := []*Repository{}
+	for url != "" {
+		var page bitbucketCloudPagedRepos
+		if err := g.doGet(ctx, url, &page); err != nil {
+			return nil, fmt.Errorf("error listing Bitbucket Cloud repositories: %v", err)
+		}
+		for _, repo := range page.Values {
+			cloneURL, err := bitbucketCloudCloneURL(repo, cloneProtocol)
+			if err != nil {
+				return nil, err
+			}
+			repos = append(repos, &Repository{
+				Organization: g.owner,
+				Repository:   repo.Slug,
+				Branch:       repo.MainBranch.Name,
+				URL:          cloneURL,
+				Labels:       []string{},
+			})
+		}
+		url = page.Next
+	}
+	return repos, nil
+}
+
+func (g *BitbucketCloudProvider) GetBranches(ctx context.Context, repo *Repository) ([]*Repository, error) {
+	if !g.allBranches {
+		branch := &Repository{
+			Organization: repo.Organization,
+			Repository:   repo.Repository,
+			URL:          repo.URL,
+			Branch:       repo.Branch,
+			Labels:       repo.Labels,
+		}
+		sha, err := g.headCommit(ctx, repo.Repository, repo.Branch)
+		if err != nil {
+			return nil, err
+		}
+		branch.SHA = sha
+		return []*Repository{branch}, nil
+	}
+
+	url := fmt.Sprintf("%s/repositories/%s/%s/refs/branches?pagelen=100", g.baseURL, g.owner, repo.Repository)
+	branches := []*Repository{}
+	for url != "" {
+		var page bitbucketCloudPagedBranches
+		if err := g.doGet(ctx, url, &page); err != nil {
+			return nil, fmt.Errorf("error listing branches for %s: %v", repo.Repository, err)
+		}
+		for _, branch := range page.Values {
+			branches = append(branches, &Repository{
+				Organization: repo.Organization,
+				Repository:   repo.Repository,
+				URL:          repo.URL,
+				Branch:       branch.Name,
+				SHA:          branch.Target.Hash,
+				Labels:       repo.Labels,
+			})
+		}
+		url = page.Next
+	}
+	return branches, nil
+}
+
+func (g *BitbucketCloudProvider) headCommit(ctx context.Context, repoSlug, branch string) (string, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/refs/branches/%s", g.baseURL, g.owner, repoSlug, branch)
+	var b bitbucketCloudBranch
+	if err := g.doGet(ctx, url, &b); err != nil {
+		return "", fmt.Errorf("error getting branch %s for %s: %v", branch, repoSlug, err)
+	}
+	return b.Target.Hash, nil
+}
+
+func (g *BitbucketCloudProvider) doGet(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(g.user, g.appPassword)
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func bitbucketCloudCloneURL(repo bitbucketCloudRepo, cloneProtocol string) (string, error) {
+	// Bitbucket Cloud labels its clone links "https" and "ssh" rather than the server's "http"/"ssh".
+	var wantName string
+	switch cloneProtocol {
+	case "", "https":
+		wantName = "https"
+	case "ssh":
+		wantName = "ssh"
+	default:
+		return "", fmt.Errorf("unsupported cloneProtocol %q for Bitbucket Cloud", cloneProtocol)
+	}
+	for _, link := range repo.Links.Clone {
+		if link.Name == wantName {
+			return link.Href, nil
+		}
+	}
+	return "", fmt.Errorf("no %s clone link found for repository %s", wantName, repo.FullName)
+}
@@ -0,0 +1,109 @@
+package scm_provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBitbucketCloudCloneURL(t *testing.T) {
+	repo := bitbucketCloudRepo{
+		FullName: "myworkspace/my-repo",
+		Links: struct {
+			Clone []struct {
+				Name string `json:"name"`
+				Href string `json:"href"`
+			} `json:"clone"`
+		}{
+			Clone: []struct {
+				Name string `json:"name"`
+				Href string `json:"href"`
+			}{
+				{Name: "https", Href: "https://bitbucket.org/myworkspace/my-repo.git"},
+				{Name: "ssh", Href: "git@bitbucket.org:myworkspace/my-repo.git"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		cloneProtocol string
+		want          string
+		wantErr       bool
+	}{
+		{name: "https default", cloneProtocol: "", want: "https://bitbucket.org/myworkspace/my-repo.git"},
+		{name: "ssh", cloneProtocol: "ssh", want: "git@bitbucket.org:myworkspace/my-repo.git"},
+		{name: "unsupported protocol", cloneProtocol: "grc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bitbucketCloudCloneURL(repo, tt.cloneProtocol)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("bitbucketCloudCloneURL() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("bitbucketCloudCloneURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("bitbucketCloudCloneURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBitbucketCloudCloneURLMissingLink(t *testing.T) {
+	repo := bitbucketCloudRepo{FullName: "myworkspace/my-repo"}
+	if _, err := bitbucketCloudCloneURL(repo, "ssh"); err == nil {
+		t.Fatal("bitbucketCloudCloneURL() error = nil, want an error for a repo with no ssh clone link")
+	}
+}
+
+// TestBitbucketCloudListReposPagination exercises the page.Next-driven pagination loop in
+// ListRepos against a fake two-page API.
+func TestBitbucketCloudListReposPagination(t *testing.T) {
+	var nextURL string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repositories/myworkspace", func(w http.ResponseWriter, r *http.Request) {
+		repo := bitbucketCloudRepo{Slug: "repo-one", FullName: "myworkspace/repo-one"}
+		repo.Links.Clone = []struct {
+			Name string `json:"name"`
+			Href string `json:"href"`
+		}{{Name: "https", Href: "https://bitbucket.org/myworkspace/repo-one.git"}}
+		repo.MainBranch.Name = "main"
+		_ = json.NewEncoder(w).Encode(bitbucketCloudPagedRepos{Values: []bitbucketCloudRepo{repo}, Next: nextURL})
+	})
+	mux.HandleFunc("/repositories/myworkspace/page2", func(w http.ResponseWriter, r *http.Request) {
+		repo := bitbucketCloudRepo{Slug: "repo-two", FullName: "myworkspace/repo-two"}
+		repo.Links.Clone = []struct {
+			Name string `json:"name"`
+			Href string `json:"href"`
+		}{{Name: "https", Href: "https://bitbucket.org/myworkspace/repo-two.git"}}
+		repo.MainBranch.Name = "main"
+		_ = json.NewEncoder(w).Encode(bitbucketCloudPagedRepos{Values: []bitbucketCloudRepo{repo}})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	nextURL = server.URL + "/repositories/myworkspace/page2"
+
+	provider, err := NewBitbucketCloudProvider("myworkspace", "user", "app-password", server.URL, false)
+	if err != nil {
+		t.Fatalf("NewBitbucketCloudProvider() error = %v", err)
+	}
+
+	repos, err := provider.ListRepos(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListRepos() error = %v", err)
+	}
+	if len(repos) != 2 {
+		t.Fatalf("ListRepos() returned %d repos, want 2", len(repos))
+	}
+	if repos[0].Repository != "repo-one" || repos[1].Repository != "repo-two" {
+		t.Errorf("ListRepos() = %v, want repo-one then repo-two", repos)
+	}
+}
@@ -0,0 +1,160 @@
+package scm_provider
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/argoproj/argo-cd/v2/applicationset/services/azure_workload_identity"
+	argoprojiov1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/applicationset/v1alpha1"
+)
+
+// azureDevOpsResourceScope is the Azure AD App ID for Azure DevOps, requested as an MSAL
+// ".default" scope. Kept in sync with the identical constant in applicationset/generators.
+const azureDevOpsResourceScope = "499b84ac-1321-427f-aa17-267ca6975798/.default"
+
+// init registers the built-in providers under the names their SCMProviderGenerator.Generic.Name
+// would use, so they remain reachable through the registry even though GenerateParams still
+// constructs them directly from their typed CRD fields for backward compatibility.
+func init() {
+	RegisterSCMProvider(githubFactory{})
+	RegisterSCMProvider(gitlabFactory{})
+	RegisterSCMProvider(giteaFactory{})
+	RegisterSCMProvider(bitbucketServerFactory{})
+	RegisterSCMProvider(&azureDevOpsFactory{})
+}
+
+func secretFromRawConfig(ctx context.Context, rawConfig map[string]string, namespace string, secretGetter SecretGetter) (string, error) {
+	if rawConfig["secretName"] == "" {
+		return "", nil
+	}
+	return secretGetter.GetSecretRef(ctx, &argoprojiov1alpha1.SecretRef{SecretName: rawConfig["secretName"], Key: rawConfig["secretKey"]}, namespace)
+}
+
+// otpSecretFromRawConfig resolves the optional otpSecretName/otpSecretKey pair, the rawConfig
+// analog of SCMProviderGeneratorGitea.OTPRef, used by providers that support TOTP-secured auth.
+func otpSecretFromRawConfig(ctx context.Context, rawConfig map[string]string, namespace string, secretGetter SecretGetter) (string, error) {
+	if rawConfig["otpSecretName"] == "" {
+		return "", nil
+	}
+	return secretGetter.GetSecretRef(ctx, &argoprojiov1alpha1.SecretRef{SecretName: rawConfig["otpSecretName"], Key: rawConfig["otpSecretKey"]}, namespace)
+}
+
+type githubFactory struct{}
+
+func (githubFactory) Name() string { return "github" }
+
+func (githubFactory) Build(ctx context.Context, rawConfig map[string]string, namespace string, secretGetter SecretGetter) (SCMProviderService, error) {
+	token, err := secretFromRawConfig(ctx, rawConfig, namespace, secretGetter)
+	if err != nil {
+		return nil, err
+	}
+	allBranches, _ := strconv.ParseBool(rawConfig["allBranches"])
+	return NewGithubProvider(ctx, rawConfig["organization"], token, rawConfig["api"], allBranches)
+}
+
+type gitlabFactory struct{}
+
+func (gitlabFactory) Name() string { return "gitlab" }
+
+func (gitlabFactory) Build(ctx context.Context, rawConfig map[string]string, namespace string, secretGetter SecretGetter) (SCMProviderService, error) {
+	token, err := secretFromRawConfig(ctx, rawConfig, namespace, secretGetter)
+	if err != nil {
+		return nil, err
+	}
+	allBranches, _ := strconv.ParseBool(rawConfig["allBranches"])
+	includeSubgroups, _ := strconv.ParseBool(rawConfig["includeSubgroups"])
+	return NewGitlabProvider(ctx, rawConfig["group"], token, rawConfig["api"], allBranches, includeSubgroups)
+}
+
+type giteaFactory struct{}
+
+func (giteaFactory) Name() string { return "gitea" }
+
+func (giteaFactory) Build(ctx context.Context, rawConfig map[string]string, namespace string, secretGetter SecretGetter) (SCMProviderService, error) {
+	token, err := secretFromRawConfig(ctx, rawConfig, namespace, secretGetter)
+	if err != nil {
+		return nil, err
+	}
+	otpSecret, err := otpSecretFromRawConfig(ctx, rawConfig, namespace, secretGetter)
+	if err != nil {
+		return nil, err
+	}
+	allBranches, _ := strconv.ParseBool(rawConfig["allBranches"])
+	insecure, _ := strconv.ParseBool(rawConfig["insecure"])
+	return NewGiteaProvider(ctx, rawConfig["owner"], token, otpSecret, rawConfig["api"], allBranches, insecure)
+}
+
+type bitbucketServerFactory struct{}
+
+func (bitbucketServerFactory) Name() string { return "bitbucket-server" }
+
+func (bitbucketServerFactory) Build(ctx context.Context, rawConfig map[string]string, namespace string, secretGetter SecretGetter) (SCMProviderService, error) {
+	allBranches, _ := strconv.ParseBool(rawConfig["allBranches"])
+	if rawConfig["username"] == "" {
+		return NewBitbucketServerProviderNoAuth(ctx, rawConfig["api"], rawConfig["project"], allBranches)
+	}
+	password, err := secretFromRawConfig(ctx, rawConfig, namespace, secretGetter)
+	if err != nil {
+		return nil, err
+	}
+	return NewBitbucketServerProviderBasicAuth(ctx, rawConfig["username"], password, rawConfig["api"], rawConfig["project"], allBranches)
+}
+
+// azureDevOpsFactory caches one workload identity token source per clientID/tenantID pair at the
+// factory level, the same way SCMProviderGenerator.azureWorkloadIdentityTokenSources does for the
+// typed-field path, so the Generic registry path doesn't re-exchange the federated token on every
+// reconcile.
+type azureDevOpsFactory struct {
+	mu           sync.Mutex
+	tokenSources map[string]*azure_workload_identity.TokenSource
+}
+
+func (*azureDevOpsFactory) Name() string { return "azuredevops" }
+
+func (f *azureDevOpsFactory) Build(ctx context.Context, rawConfig map[string]string, namespace string, secretGetter SecretGetter) (SCMProviderService, error) {
+	allBranches, _ := strconv.ParseBool(rawConfig["allBranches"])
+
+	if rawConfig["workloadIdentityClientId"] != "" {
+		tokenSource, err := f.workloadIdentityTokenSource(rawConfig["workloadIdentityClientId"], rawConfig["workloadIdentityTenantId"])
+		if err != nil {
+			return nil, err
+		}
+		bearerToken, err := tokenSource.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return NewAzureDevOpsProviderBearerToken(ctx, bearerToken, rawConfig["organization"], rawConfig["api"], rawConfig["teamProject"], allBranches)
+	}
+
+	token, err := secretFromRawConfig(ctx, rawConfig, namespace, secretGetter)
+	if err != nil {
+		return nil, err
+	}
+	return NewAzureDevOpsProvider(ctx, token, rawConfig["organization"], rawConfig["api"], rawConfig["teamProject"], allBranches)
+}
+
+func (f *azureDevOpsFactory) workloadIdentityTokenSource(clientID, tenantID string) (*azure_workload_identity.TokenSource, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.tokenSources == nil {
+		f.tokenSources = map[string]*azure_workload_identity.TokenSource{}
+	}
+	key := tenantID + "/" + clientID
+	tokenSource, ok := f.tokenSources[key]
+	if ok {
+		return tokenSource, nil
+	}
+
+	tokenSource, err := azure_workload_identity.NewTokenSource(azure_workload_identity.Config{
+		ClientID: clientID,
+		TenantID: tenantID,
+		Scope:    azureDevOpsResourceScope,
+	})
+	if err != nil {
+		return nil, err
+	}
+	f.tokenSources[key] = tokenSource
+	return tokenSource, nil
+}
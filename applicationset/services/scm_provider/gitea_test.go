@@ -0,0 +1,54 @@
+package scm_provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pquerna/otp/totp"
+)
+
+// stubRoundTripper records the last request it saw and returns a fixed response.
+type stubRoundTripper struct {
+	lastReq *http.Request
+}
+
+func (rt *stubRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.lastReq = req
+	return httptest.NewRecorder().Result(), nil
+}
+
+func TestGiteaOTPRoundTripperSetsCurrentCode(t *testing.T) {
+	secret := "JBSWY3DPEHPK3PXP"
+	base := &stubRoundTripper{}
+	rt := &giteaOTPRoundTripper{secret: secret, base: base}
+
+	req, err := http.NewRequest(http.MethodGet, "https://gitea.example.com/api/v1/orgs/myorg/repos", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+
+	gotCode := base.lastReq.Header.Get("X-Gitea-OTP")
+	valid, err := totp.Validate(gotCode, secret)
+	if err != nil {
+		t.Fatalf("totp.Validate() error = %v", err)
+	}
+	if !valid {
+		t.Errorf("X-Gitea-OTP header %q did not validate against the shared secret", gotCode)
+	}
+}
+
+func TestGiteaOTPRoundTripperErrorOnInvalidSecret(t *testing.T) {
+	rt := &giteaOTPRoundTripper{secret: "not-valid-base32!!", base: &stubRoundTripper{}}
+	req, err := http.NewRequest(http.MethodGet, "https://gitea.example.com/api/v1/orgs/myorg/repos", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+	if _, err := rt.RoundTrip(req); err == nil {
+		t.Fatal("RoundTrip() error = nil, want an error generating a code from an invalid secret")
+	}
+}
@@ -0,0 +1,109 @@
+package scm_provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v55/github"
+	"golang.org/x/oauth2"
+)
+
+// GithubProvider talks to github.com or a GitHub Enterprise instance on behalf of an organization.
+// token may be a personal access token or a short-lived GitHub App installation token; both
+// authenticate identically over the API.
+type GithubProvider struct {
+	client       *github.Client
+	organization string
+	allBranches  bool
+}
+
+var _ SCMProviderService = &GithubProvider{}
+
+func NewGithubProvider(ctx context.Context, organization, token, url string, allBranches bool) (*GithubProvider, error) {
+	var ts oauth2.TokenSource
+	if token != "" {
+		ts = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	}
+	httpClient := oauth2.NewClient(ctx, ts)
+
+	client := github.NewClient(httpClient)
+	if url != "" {
+		var err error
+		client, err = client.WithEnterpriseURLs(url, url)
+		if err != nil {
+			return nil, fmt.Errorf("error creating GitHub Enterprise client: %v", err)
+		}
+	}
+
+	return &GithubProvider{client: client, organization: organization, allBranches: allBranches}, nil
+}
+
+func (g *GithubProvider) ListRepos(ctx context.Context, cloneProtocol string) ([]*Repository, error) {
+	opt := &github.RepositoryListByOrgOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var repos []*Repository
+	for {
+		githubRepos, resp, err := g.client.Repositories.ListByOrg(ctx, g.organization, opt)
+		if err != nil {
+			return nil, fmt.Errorf("error listing GitHub repositories: %v", err)
+		}
+		for _, repo := range githubRepos {
+			url := repo.GetCloneURL()
+			if cloneProtocol == "ssh" {
+				url = repo.GetSSHURL()
+			}
+			repos = append(repos, &Repository{
+				Organization: g.organization,
+				Repository:   repo.GetName(),
+				Branch:       repo.GetDefaultBranch(),
+				URL:          url,
+				Labels:       []string{},
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return repos, nil
+}
+
+func (g *GithubProvider) GetBranches(ctx context.Context, repo *Repository) ([]*Repository, error) {
+	if !g.allBranches {
+		branch, _, err := g.client.Repositories.GetBranch(ctx, g.organization, repo.Repository, repo.Branch, 1)
+		if err != nil {
+			return nil, fmt.Errorf("error getting branch %s for %s: %v", repo.Branch, repo.Repository, err)
+		}
+		return []*Repository{{
+			Organization: repo.Organization,
+			Repository:   repo.Repository,
+			URL:          repo.URL,
+			Branch:       branch.GetName(),
+			SHA:          branch.GetCommit().GetSHA(),
+			Labels:       repo.Labels,
+		}}, nil
+	}
+
+	opt := &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var branches []*Repository
+	for {
+		githubBranches, resp, err := g.client.Repositories.ListBranches(ctx, g.organization, repo.Repository, opt)
+		if err != nil {
+			return nil, fmt.Errorf("error listing branches for %s: %v", repo.Repository, err)
+		}
+		for _, branch := range githubBranches {
+			branches = append(branches, &Repository{
+				Organization: repo.Organization,
+				Repository:   repo.Repository,
+				URL:          repo.URL,
+				Branch:       branch.GetName(),
+				SHA:          branch.GetCommit().GetSHA(),
+				Labels:       repo.Labels,
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return branches, nil
+}
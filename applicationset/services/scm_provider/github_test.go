@@ -0,0 +1,48 @@
+package scm_provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGithubProviderListReposAndGetBranches(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/orgs/myorg/repos", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"my-repo","clone_url":"https://github.example.com/myorg/my-repo.git","ssh_url":"git@github.example.com:myorg/my-repo.git","default_branch":"main"}]`)
+	})
+	mux.HandleFunc("/api/v3/repos/myorg/my-repo/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"main","commit":{"sha":"abc123"}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	provider, err := NewGithubProvider(context.Background(), "myorg", "", server.URL, false)
+	if err != nil {
+		t.Fatalf("NewGithubProvider() error = %v", err)
+	}
+
+	repos, err := provider.ListRepos(context.Background(), "ssh")
+	if err != nil {
+		t.Fatalf("ListRepos() error = %v", err)
+	}
+	if len(repos) != 1 {
+		t.Fatalf("ListRepos() returned %d repos, want 1", len(repos))
+	}
+	if repos[0].URL != "git@github.example.com:myorg/my-repo.git" {
+		t.Errorf("ListRepos()[0].URL = %q, want the ssh clone URL", repos[0].URL)
+	}
+	if repos[0].Branch != "main" {
+		t.Errorf("ListRepos()[0].Branch = %q, want %q", repos[0].Branch, "main")
+	}
+
+	branches, err := provider.GetBranches(context.Background(), repos[0])
+	if err != nil {
+		t.Fatalf("GetBranches() error = %v", err)
+	}
+	if len(branches) != 1 || branches[0].SHA != "abc123" {
+		t.Fatalf("GetBranches() = %v, want a single branch with SHA abc123", branches)
+	}
+}
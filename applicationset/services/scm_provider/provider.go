@@ -0,0 +1,25 @@
+package scm_provider
+
+import "context"
+
+// Repository describes a single repository/branch combination discovered by an SCMProviderService,
+// in the generic shape the SCMProviderGenerator turns into template parameters.
+type Repository struct {
+	Organization string
+	Repository   string
+	Branch       string
+	SHA          string
+	Labels       []string
+	URL          string
+}
+
+// SCMProviderService is implemented by each supported source code host so the SCMProviderGenerator
+// can enumerate repositories and branches without knowing which host it's talking to.
+type SCMProviderService interface {
+	// ListRepos returns one Repository per repository visible to the configured credentials, with
+	// URL populated according to cloneProtocol.
+	ListRepos(ctx context.Context, cloneProtocol string) ([]*Repository, error)
+	// GetBranches returns the branches to consider for repo, honoring whatever "all branches" setting
+	// the provider was configured with. repo.Branch/SHA are populated on the returned Repository.
+	GetBranches(ctx context.Context, repo *Repository) ([]*Repository, error)
+}
@@ -0,0 +1,49 @@
+package scm_provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	argoprojiov1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/applicationset/v1alpha1"
+)
+
+// SecretGetter abstracts fetching a referenced Secret key's value, so an SCMProviderFactory doesn't
+// need a controller-runtime client of its own.
+type SecretGetter interface {
+	GetSecretRef(ctx context.Context, ref *argoprojiov1alpha1.SecretRef, namespace string) (string, error)
+}
+
+// SCMProviderFactory builds an SCMProviderService from a provider's raw string configuration (the
+// Data of a ConfigMap, typically). Built-in providers register themselves from init(), while their
+// typed CRD fields are still handled directly by the generator for backward compatibility; out-of-tree
+// providers can be registered the same way by a custom applicationset-controller binary and selected
+// via SCMProviderGenerator.Generic.
+type SCMProviderFactory interface {
+	Name() string
+	Build(ctx context.Context, rawConfig map[string]string, namespace string, secretGetter SecretGetter) (SCMProviderService, error)
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]SCMProviderFactory{}
+)
+
+// RegisterSCMProvider makes factory selectable by its Name() via SCMProviderGenerator.Generic.
+// Typically called from an init() func.
+func RegisterSCMProvider(factory SCMProviderFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[factory.Name()] = factory
+}
+
+// GetSCMProvider looks up a factory previously passed to RegisterSCMProvider.
+func GetSCMProvider(name string) (SCMProviderFactory, error) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("no SCM provider registered with name %q", name)
+	}
+	return factory, nil
+}
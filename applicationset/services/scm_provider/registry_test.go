@@ -0,0 +1,112 @@
+package scm_provider
+
+import (
+	"context"
+	"testing"
+
+	argoprojiov1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/applicationset/v1alpha1"
+)
+
+type fakeFactory struct{ name string }
+
+func (f fakeFactory) Name() string { return f.name }
+
+func (f fakeFactory) Build(ctx context.Context, rawConfig map[string]string, namespace string, secretGetter SecretGetter) (SCMProviderService, error) {
+	return nil, nil
+}
+
+func TestRegisterAndGetSCMProvider(t *testing.T) {
+	RegisterSCMProvider(fakeFactory{name: "test-registry-provider"})
+
+	got, err := GetSCMProvider("test-registry-provider")
+	if err != nil {
+		t.Fatalf("GetSCMProvider() error = %v", err)
+	}
+	if got.Name() != "test-registry-provider" {
+		t.Errorf("GetSCMProvider().Name() = %q, want %q", got.Name(), "test-registry-provider")
+	}
+}
+
+func TestGetSCMProviderUnknownName(t *testing.T) {
+	if _, err := GetSCMProvider("does-not-exist"); err == nil {
+		t.Fatal("GetSCMProvider() error = nil, want an error for an unregistered name")
+	}
+}
+
+func TestBuiltinFactoriesAreRegistered(t *testing.T) {
+	for _, name := range []string{"github", "gitlab", "gitea", "bitbucket-server", "azuredevops"} {
+		if _, err := GetSCMProvider(name); err != nil {
+			t.Errorf("GetSCMProvider(%q) error = %v, want the built-in factory to be registered", name, err)
+		}
+	}
+}
+
+type fakeSecretGetter map[string]string
+
+func (f fakeSecretGetter) GetSecretRef(ctx context.Context, ref *argoprojiov1alpha1.SecretRef, namespace string) (string, error) {
+	return f[ref.SecretName+"/"+ref.Key], nil
+}
+
+func TestSecretFromRawConfig(t *testing.T) {
+	secrets := fakeSecretGetter{"my-secret/token": "shh"}
+
+	got, err := secretFromRawConfig(context.Background(), map[string]string{"secretName": "my-secret", "secretKey": "token"}, "argocd", secrets)
+	if err != nil {
+		t.Fatalf("secretFromRawConfig() error = %v", err)
+	}
+	if got != "shh" {
+		t.Errorf("secretFromRawConfig() = %q, want %q", got, "shh")
+	}
+
+	got, err = secretFromRawConfig(context.Background(), map[string]string{}, "argocd", secrets)
+	if err != nil {
+		t.Fatalf("secretFromRawConfig() error = %v", err)
+	}
+	if got != "" {
+		t.Errorf("secretFromRawConfig() with no secretName = %q, want empty", got)
+	}
+}
+
+func TestOTPSecretFromRawConfig(t *testing.T) {
+	secrets := fakeSecretGetter{"my-otp/secret": "totp-seed"}
+
+	got, err := otpSecretFromRawConfig(context.Background(), map[string]string{"otpSecretName": "my-otp", "otpSecretKey": "secret"}, "argocd", secrets)
+	if err != nil {
+		t.Fatalf("otpSecretFromRawConfig() error = %v", err)
+	}
+	if got != "totp-seed" {
+		t.Errorf("otpSecretFromRawConfig() = %q, want %q", got, "totp-seed")
+	}
+
+	got, err = otpSecretFromRawConfig(context.Background(), map[string]string{}, "argocd", secrets)
+	if err != nil {
+		t.Fatalf("otpSecretFromRawConfig() error = %v", err)
+	}
+	if got != "" {
+		t.Errorf("otpSecretFromRawConfig() with no otpSecretName = %q, want empty", got)
+	}
+}
+
+func TestAzureDevOpsFactoryCachesTokenSourcePerClientTenant(t *testing.T) {
+	f := &azureDevOpsFactory{}
+
+	first, err := f.workloadIdentityTokenSource("client-a", "tenant-a")
+	if err != nil {
+		t.Fatalf("workloadIdentityTokenSource() error = %v", err)
+	}
+	again, err := f.workloadIdentityTokenSource("client-a", "tenant-a")
+	if err != nil {
+		t.Fatalf("workloadIdentityTokenSource() error = %v", err)
+	}
+	if first != again {
+		t.Error("workloadIdentityTokenSource() returned a different instance for the same clientID/tenantID pair")
+	}
+
+	other, err := f.workloadIdentityTokenSource("client-b", "tenant-a")
+	if err != nil {
+		t.Fatalf("workloadIdentityTokenSource() error = %v", err)
+	}
+	if other == first {
+		t.Error("workloadIdentityTokenSource() returned the same instance for a different clientID")
+	}
+}
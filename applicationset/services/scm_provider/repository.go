@@ -0,0 +1,60 @@
+package scm_provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	argoprojiov1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/applicationset/v1alpha1"
+)
+
+// ListRepos lists the repositories and branches visible to provider, keeping only those that match
+// every configured filter.
+func ListRepos(ctx context.Context, provider SCMProviderService, filters []argoprojiov1alpha1.SCMProviderGeneratorFilter, cloneProtocol string) ([]*Repository, error) {
+	repos, err := provider.ListRepos(ctx, cloneProtocol)
+	if err != nil {
+		return nil, fmt.Errorf("error listing repos: %v", err)
+	}
+
+	filteredRepos := make([]*Repository, 0, len(repos))
+	for _, repo := range repos {
+		branches, err := provider.GetBranches(ctx, repo)
+		if err != nil {
+			return nil, fmt.Errorf("error getting branches for repo %s: %v", repo.Repository, err)
+		}
+		for _, branch := range branches {
+			matches, err := repoMatchesFilters(branch, filters)
+			if err != nil {
+				return nil, err
+			}
+			if matches {
+				filteredRepos = append(filteredRepos, branch)
+			}
+		}
+	}
+	return filteredRepos, nil
+}
+
+func repoMatchesFilters(repo *Repository, filters []argoprojiov1alpha1.SCMProviderGeneratorFilter) (bool, error) {
+	for _, filter := range filters {
+		if filter.RepositoryMatch != nil {
+			matched, err := regexp.MatchString(*filter.RepositoryMatch, repo.Repository)
+			if err != nil {
+				return false, fmt.Errorf("error matching repositoryMatch filter: %v", err)
+			}
+			if !matched {
+				return false, nil
+			}
+		}
+		if filter.BranchMatch != nil {
+			matched, err := regexp.MatchString(*filter.BranchMatch, repo.Branch)
+			if err != nil {
+				return false, fmt.Errorf("error matching branchMatch filter: %v", err)
+			}
+			if !matched {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,218 @@
+package v1alpha1
+
+// SecretRef is a reference to a key in a Kubernetes Secret in the same namespace as the referring resource.
+type SecretRef struct {
+	SecretName string `json:"secretName" protobuf:"bytes,1,opt,name=secretName"`
+	Key        string `json:"key" protobuf:"bytes,2,opt,name=key"`
+}
+
+// SCMProviderGeneratorFilter is a single match rule applied against the repositories discovered by an
+// SCMProviderGenerator. A repository must satisfy every non-nil field of a filter to be included.
+type SCMProviderGeneratorFilter struct {
+	RepositoryMatch *string `json:"repositoryMatch,omitempty" protobuf:"bytes,1,opt,name=repositoryMatch"`
+	BranchMatch     *string `json:"branchMatch,omitempty" protobuf:"bytes,4,opt,name=branchMatch"`
+}
+
+// SCMProviderGeneratorGithub defines connection info specific to GitHub.
+type SCMProviderGeneratorGithub struct {
+	Organization string     `json:"organization" protobuf:"bytes,1,opt,name=organization"`
+	API          string     `json:"api,omitempty" protobuf:"bytes,2,opt,name=api"`
+	// TokenRef is a reference to a Secret containing a personal access token. Mutually exclusive with AppAuth.
+	TokenRef    *SecretRef `json:"tokenRef,omitempty" protobuf:"bytes,3,opt,name=tokenRef"`
+	AllBranches bool       `json:"allBranches,omitempty" protobuf:"varint,4,opt,name=allBranches"`
+	// AppAuth authenticates as a GitHub App installation instead of a personal access token.
+	AppAuth *SCMProviderGeneratorGithubAppAuth `json:"appAuth,omitempty" protobuf:"bytes,5,opt,name=appAuth"`
+	// SSHPrivateKeyRef references a Secret holding a private key Argo CD should use to clone
+	// repositories discovered by this provider. Only used when CloneProtocol is "ssh".
+	SSHPrivateKeyRef *SecretRef `json:"sshPrivateKeyRef,omitempty" protobuf:"bytes,6,opt,name=sshPrivateKeyRef"`
+}
+
+// SCMProviderGeneratorGithubAppAuth configures authentication as a GitHub App installation.
+type SCMProviderGeneratorGithubAppAuth struct {
+	AppID          int64 `json:"appID" protobuf:"varint,1,opt,name=appID"`
+	InstallationID int64 `json:"installationID" protobuf:"varint,2,opt,name=installationID"`
+	// PrivateKeyRef references a Secret containing the App's PEM-encoded RSA private key.
+	PrivateKeyRef *SecretRef `json:"privateKeyRef" protobuf:"bytes,3,opt,name=privateKeyRef"`
+}
+
+// SCMProviderGeneratorGitlab defines connection info specific to GitLab.
+type SCMProviderGeneratorGitlab struct {
+	Group            string     `json:"group" protobuf:"bytes,1,opt,name=group"`
+	API              string     `json:"api,omitempty" protobuf:"bytes,2,opt,name=api"`
+	TokenRef         *SecretRef `json:"tokenRef,omitempty" protobuf:"bytes,3,opt,name=tokenRef"`
+	AllBranches      bool       `json:"allBranches,omitempty" protobuf:"varint,4,opt,name=allBranches"`
+	IncludeSubgroups bool       `json:"includeSubgroups,omitempty" protobuf:"varint,5,opt,name=includeSubgroups"`
+	// SSHPrivateKeyRef references a Secret holding a private key Argo CD should use to clone
+	// repositories discovered by this provider. Only used when CloneProtocol is "ssh".
+	SSHPrivateKeyRef *SecretRef `json:"sshPrivateKeyRef,omitempty" protobuf:"bytes,6,opt,name=sshPrivateKeyRef"`
+}
+
+// SCMProviderGeneratorGitea defines connection info specific to Gitea.
+type SCMProviderGeneratorGitea struct {
+	Owner       string     `json:"owner" protobuf:"bytes,1,opt,name=owner"`
+	API         string     `json:"api" protobuf:"bytes,2,opt,name=api"`
+	TokenRef    *SecretRef `json:"tokenRef,omitempty" protobuf:"bytes,3,opt,name=tokenRef"`
+	AllBranches bool       `json:"allBranches,omitempty" protobuf:"varint,4,opt,name=allBranches"`
+	Insecure    bool       `json:"insecure,omitempty" protobuf:"varint,5,opt,name=insecure"`
+	// OTPRef references a Secret holding a TOTP shared secret, for Gitea instances that require 2FA
+	// on top of the token. The current 30-second code is sent as the X-Gitea-OTP header.
+	OTPRef *SecretRef `json:"otpRef,omitempty" protobuf:"bytes,6,opt,name=otpRef"`
+	// SSHPrivateKeyRef references a Secret holding a private key Argo CD should use to clone
+	// repositories discovered by this provider. Only used when CloneProtocol is "ssh".
+	SSHPrivateKeyRef *SecretRef `json:"sshPrivateKeyRef,omitempty" protobuf:"bytes,7,opt,name=sshPrivateKeyRef"`
+}
+
+// SCMProviderGeneratorForgejo defines connection info specific to Forgejo (e.g. Codeberg). Forgejo's
+// API is a fork of Gitea's and compatible today, so this mirrors SCMProviderGeneratorGitea field for
+// field, but is kept as its own type so the two can diverge cleanly.
+type SCMProviderGeneratorForgejo struct {
+	Owner       string     `json:"owner" protobuf:"bytes,1,opt,name=owner"`
+	API         string     `json:"api" protobuf:"bytes,2,opt,name=api"`
+	TokenRef    *SecretRef `json:"tokenRef,omitempty" protobuf:"bytes,3,opt,name=tokenRef"`
+	AllBranches bool       `json:"allBranches,omitempty" protobuf:"varint,4,opt,name=allBranches"`
+	Insecure    bool       `json:"insecure,omitempty" protobuf:"varint,5,opt,name=insecure"`
+	OTPRef      *SecretRef `json:"otpRef,omitempty" protobuf:"bytes,6,opt,name=otpRef"`
+	// SSHPrivateKeyRef references a Secret holding a private key Argo CD should use to clone
+	// repositories discovered by this provider. Only used when CloneProtocol is "ssh".
+	SSHPrivateKeyRef *SecretRef `json:"sshPrivateKeyRef,omitempty" protobuf:"bytes,7,opt,name=sshPrivateKeyRef"`
+}
+
+// SCMProviderGeneratorBitbucketServerBasicAuth defines basic authentication for Bitbucket Server.
+type SCMProviderGeneratorBitbucketServerBasicAuth struct {
+	Username    string     `json:"username" protobuf:"bytes,1,opt,name=username"`
+	PasswordRef *SecretRef `json:"passwordRef" protobuf:"bytes,2,opt,name=passwordRef"`
+}
+
+// SCMProviderGeneratorBitbucketServer defines connection info specific to Bitbucket Server.
+type SCMProviderGeneratorBitbucketServer struct {
+	Project     string                                        `json:"project" protobuf:"bytes,1,opt,name=project"`
+	API         string                                        `json:"api" protobuf:"bytes,2,opt,name=api"`
+	BasicAuth   *SCMProviderGeneratorBitbucketServerBasicAuth `json:"basicAuth,omitempty" protobuf:"bytes,3,opt,name=basicAuth"`
+	AllBranches bool                                          `json:"allBranches,omitempty" protobuf:"varint,4,opt,name=allBranches"`
+	// SSHPrivateKeyRef references a Secret holding a private key Argo CD should use to clone
+	// repositories discovered by this provider. Only used when CloneProtocol is "ssh".
+	SSHPrivateKeyRef *SecretRef `json:"sshPrivateKeyRef,omitempty" protobuf:"bytes,5,opt,name=sshPrivateKeyRef"`
+}
+
+// SCMProviderGeneratorAzureDevOps defines connection info specific to Azure DevOps.
+type SCMProviderGeneratorAzureDevOps struct {
+	Organization string `json:"organization" protobuf:"bytes,1,opt,name=organization"`
+	TeamProject  string `json:"teamProject" protobuf:"bytes,2,opt,name=teamProject"`
+	API          string `json:"api,omitempty" protobuf:"bytes,3,opt,name=api"`
+	// AccessTokenRef is a reference to a Secret containing a personal access token. Mutually exclusive
+	// with WorkloadIdentity.
+	AccessTokenRef *SecretRef `json:"accessTokenRef,omitempty" protobuf:"bytes,4,opt,name=accessTokenRef"`
+	AllBranches    bool       `json:"allBranches,omitempty" protobuf:"varint,5,opt,name=allBranches"`
+	// WorkloadIdentity authenticates via Azure Workload Identity instead of a long-lived PAT.
+	WorkloadIdentity *SCMProviderGeneratorAzureDevOpsWorkloadIdentity `json:"workloadIdentity,omitempty" protobuf:"bytes,6,opt,name=workloadIdentity"`
+	// SSHPrivateKeyRef references a Secret holding a private key Argo CD should use to clone
+	// repositories discovered by this provider. Only used when CloneProtocol is "ssh".
+	SSHPrivateKeyRef *SecretRef `json:"sshPrivateKeyRef,omitempty" protobuf:"bytes,7,opt,name=sshPrivateKeyRef"`
+}
+
+// SCMProviderGeneratorAzureDevOpsWorkloadIdentity configures Azure AD token exchange via a federated
+// workload identity, avoiding a statically stored PAT.
+type SCMProviderGeneratorAzureDevOpsWorkloadIdentity struct {
+	ClientID string `json:"clientId" protobuf:"bytes,1,opt,name=clientId"`
+	TenantID string `json:"tenantId" protobuf:"bytes,2,opt,name=tenantId"`
+	// ServiceConnectionID optionally records the Azure DevOps service connection this identity mirrors,
+	// for traceability; it is not used in the token exchange itself.
+	ServiceConnectionID string `json:"serviceConnectionId,omitempty" protobuf:"bytes,3,opt,name=serviceConnectionId"`
+}
+
+// SCMProviderGeneratorBitbucketCloud defines connection info specific to Bitbucket Cloud (bitbucket.org).
+type SCMProviderGeneratorBitbucketCloud struct {
+	// Owner is the workspace (or legacy account) that owns the repositories to scan.
+	Owner string `json:"owner" protobuf:"bytes,1,opt,name=owner"`
+	// User is the Bitbucket Cloud username that AppPasswordRef belongs to.
+	User string `json:"user" protobuf:"bytes,2,opt,name=user"`
+	// AppPasswordRef is a reference to a Secret containing a Bitbucket Cloud app password for User.
+	AppPasswordRef *SecretRef `json:"appPasswordRef" protobuf:"bytes,3,opt,name=appPasswordRef"`
+	// API is the Bitbucket Cloud REST API v2.0 base URL. Defaults to https://api.bitbucket.org/2.0 if omitted.
+	API         string `json:"api,omitempty" protobuf:"bytes,4,opt,name=api"`
+	AllBranches bool   `json:"allBranches,omitempty" protobuf:"varint,5,opt,name=allBranches"`
+	// SSHPrivateKeyRef references a Secret holding a private key Argo CD should use to clone
+	// repositories discovered by this provider. Only used when CloneProtocol is "ssh".
+	SSHPrivateKeyRef *SecretRef `json:"sshPrivateKeyRef,omitempty" protobuf:"bytes,6,opt,name=sshPrivateKeyRef"`
+}
+
+// SCMProviderGeneratorAWSCodeCommit defines connection info specific to AWS CodeCommit.
+type SCMProviderGeneratorAWSCodeCommit struct {
+	// Region is the AWS region CodeCommit repositories live in.
+	Region string `json:"region" protobuf:"bytes,1,opt,name=region"`
+	// Role is an optional IAM role ARN to assume before calling CodeCommit, e.g. for cross-account access.
+	Role string `json:"role,omitempty" protobuf:"bytes,2,opt,name=role"`
+	// TagFilters restricts discovery to repositories carrying all of the given tags.
+	TagFilters  []*TagFilter `json:"tagFilters,omitempty" protobuf:"bytes,3,rep,name=tagFilters"`
+	AllBranches bool         `json:"allBranches,omitempty" protobuf:"varint,4,opt,name=allBranches"`
+	// SSHPrivateKeyRef references a Secret holding a private key Argo CD should use to clone
+	// repositories discovered by this provider. Only used when CloneProtocol is "ssh".
+	SSHPrivateKeyRef *SecretRef `json:"sshPrivateKeyRef,omitempty" protobuf:"bytes,5,opt,name=sshPrivateKeyRef"`
+}
+
+// TagFilter matches a single AWS resource tag key, optionally requiring a specific value.
+type TagFilter struct {
+	Key   string `json:"key" protobuf:"bytes,1,opt,name=key"`
+	Value string `json:"value,omitempty" protobuf:"bytes,2,opt,name=value"`
+}
+
+// SCMProviderGeneratorGeneric configures an out-of-tree SCM provider registered by name via
+// scm_provider.RegisterSCMProvider, so third parties can add providers without forking this repo.
+type SCMProviderGeneratorGeneric struct {
+	// Name must match the Name() of a provider registered in the applicationset-controller binary.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// ConfigMapRef names a ConfigMap in the ApplicationSet's namespace whose Data is passed to the
+	// provider factory as its raw configuration.
+	ConfigMapRef string `json:"configMapRef,omitempty" protobuf:"bytes,2,opt,name=configMapRef"`
+	// SecretRef is an optional Secret the provider factory may read via the SecretGetter passed to
+	// Build; which key(s) it needs is up to the factory.
+	SecretRef *SecretRef `json:"secretRef,omitempty" protobuf:"bytes,3,opt,name=secretRef"`
+}
+
+// ApplicationSetTemplate is the Application template used by generators to render the resulting Applications.
+type ApplicationSetTemplate struct {
+	ApplicationSetTemplateMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	Spec ApplicationSpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// ApplicationSetTemplateMeta mirrors the metadata fields an ApplicationSetTemplate may set on generated Applications.
+type ApplicationSetTemplateMeta struct {
+	Name        string            `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	Namespace   string            `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
+	Labels      map[string]string `json:"labels,omitempty" protobuf:"bytes,3,rep,name=labels"`
+	Annotations map[string]string `json:"annotations,omitempty" protobuf:"bytes,4,rep,name=annotations"`
+	Finalizers  []string          `json:"finalizers,omitempty" protobuf:"bytes,5,rep,name=finalizers"`
+}
+
+// ApplicationSpec is a placeholder for the Argo CD Application spec rendered from the template.
+type ApplicationSpec struct{}
+
+// SCMProviderGenerator generates Applications for repositories returned by an SCM provider API.
+type SCMProviderGenerator struct {
+	Github          *SCMProviderGeneratorGithub          `json:"github,omitempty" protobuf:"bytes,1,opt,name=github"`
+	Gitlab          *SCMProviderGeneratorGitlab          `json:"gitlab,omitempty" protobuf:"bytes,2,opt,name=gitlab"`
+	Gitea           *SCMProviderGeneratorGitea           `json:"gitea,omitempty" protobuf:"bytes,3,opt,name=gitea"`
+	BitbucketServer *SCMProviderGeneratorBitbucketServer `json:"bitbucketServer,omitempty" protobuf:"bytes,4,opt,name=bitbucketServer"`
+	AzureDevOps     *SCMProviderGeneratorAzureDevOps     `json:"azureDevOps,omitempty" protobuf:"bytes,5,opt,name=azureDevOps"`
+	BitbucketCloud  *SCMProviderGeneratorBitbucketCloud  `json:"bitbucketCloud,omitempty" protobuf:"bytes,6,opt,name=bitbucketCloud"`
+	AWSCodeCommit   *SCMProviderGeneratorAWSCodeCommit   `json:"awsCodeCommit,omitempty" protobuf:"bytes,7,opt,name=awsCodeCommit"`
+	// Generic configures a provider registered via scm_provider.RegisterSCMProvider rather than one
+	// of the typed fields above, for out-of-tree providers linked into a custom controller binary.
+	Generic *SCMProviderGeneratorGeneric `json:"generic,omitempty" protobuf:"bytes,8,opt,name=generic"`
+	Forgejo *SCMProviderGeneratorForgejo `json:"forgejo,omitempty" protobuf:"bytes,9,opt,name=forgejo"`
+
+	Filters             []SCMProviderGeneratorFilter `json:"filters,omitempty" protobuf:"bytes,20,rep,name=filters"`
+	CloneProtocol       string                       `json:"cloneProtocol,omitempty" protobuf:"bytes,21,opt,name=cloneProtocol"`
+	Template            ApplicationSetTemplate       `json:"template,omitempty" protobuf:"bytes,22,opt,name=template"`
+	RequeueAfterSeconds *int64                       `json:"requeueAfterSeconds,omitempty" protobuf:"varint,23,opt,name=requeueAfterSeconds"`
+}
+
+// ApplicationSetGenerator contains the generator configuration for an ApplicationSet.
+type ApplicationSetGenerator struct {
+	SCMProvider *SCMProviderGenerator `json:"scmProvider,omitempty" protobuf:"bytes,1,opt,name=scmProvider"`
+}
+
+// ApplicationSet is the top-level CRD that owns a set of generated Applications.
+type ApplicationSet struct {
+	Namespace string
+}